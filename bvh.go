@@ -0,0 +1,349 @@
+package rt
+
+import "math"
+
+// AABB is an axis-aligned bounding box
+type AABB struct {
+	Min, Max P3
+}
+
+// boundsOfPoints returns the smallest AABB enclosing the given points
+func boundsOfPoints(pts ...P3) AABB {
+	min, max := pts[0], pts[0]
+	for _, p := range pts[1:] {
+		min = P3{X: math.Min(min.X, p.X), Y: math.Min(min.Y, p.Y), Z: math.Min(min.Z, p.Z)}
+		max = P3{X: math.Max(max.X, p.X), Y: math.Max(max.Y, p.Y), Z: math.Max(max.Z, p.Z)}
+	}
+	return AABB{Min: min, Max: max}
+}
+
+// unionAABB returns the smallest AABB enclosing both a and b
+func unionAABB(a, b AABB) AABB {
+	return AABB{
+		Min: P3{X: math.Min(a.Min.X, b.Min.X), Y: math.Min(a.Min.Y, b.Min.Y), Z: math.Min(a.Min.Z, b.Min.Z)},
+		Max: P3{X: math.Max(a.Max.X, b.Max.X), Y: math.Max(a.Max.Y, b.Max.Y), Z: math.Max(a.Max.Z, b.Max.Z)},
+	}
+}
+
+// axisVal returns the component of p along the given axis (0=X, 1=Y, 2=Z)
+func axisVal(p P3, axis int) float64 {
+	switch axis {
+	case 0:
+		return p.X
+	case 1:
+		return p.Y
+	default:
+		return p.Z
+	}
+}
+
+// centroid returns the centre point of the box
+func (b AABB) centroid() P3 {
+	return b.Min.Add(b.Max).Scale(0.5)
+}
+
+// surfaceArea returns the total surface area of the box, used by the SAH cost function
+func (b AABB) surfaceArea() float64 {
+	d := b.Max.Sub(b.Min)
+	return 2 * (d.X*d.Y + d.Y*d.Z + d.Z*d.X)
+}
+
+// longestAxis returns the axis (0=X, 1=Y, 2=Z) along which the box is widest
+func (b AABB) longestAxis() int {
+	d := b.Max.Sub(b.Min)
+	axis, longest := 0, d.X
+	if d.Y > longest {
+		axis, longest = 1, d.Y
+	}
+	if d.Z > longest {
+		axis = 2
+	}
+	return axis
+}
+
+// Intersect is a slab test: it returns the near and far ray parameters at
+// which r crosses the box, and whether it crosses it at all
+func (b AABB) Intersect(r R3) (tmin, tmax float64, hit bool) {
+	tmin, tmax = math.Inf(-1), math.Inf(1)
+	for axis := 0; axis < 3; axis++ {
+		o := axisVal(r.At, axis)
+		d := axisVal(r.Dir, axis)
+		lo := axisVal(b.Min, axis)
+		hi := axisVal(b.Max, axis)
+
+		if math.Abs(d) < Epsilon {
+			if o < lo || o > hi {
+				return 0, 0, false
+			}
+			continue
+		}
+
+		t1, t2 := (lo-o)/d, (hi-o)/d
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		if t1 > tmin {
+			tmin = t1
+		}
+		if t2 < tmax {
+			tmax = t2
+		}
+		if tmin > tmax {
+			return 0, 0, false
+		}
+	}
+	return tmin, tmax, true
+}
+
+// rayParam returns the ray parameter t at which r reaches the point p,
+// i.e. the t for which r.At + r.Dir.Scale(t) == p
+func rayParam(r R3, p P3) float64 {
+	return p.Sub(r.At).Dot(r.Dir) / r.Dir.Dot(r.Dir)
+}
+
+// SAH tuning constants, following the standard cost model
+// C = Ct + (SA(L)/SA(P))*NL*Ci + (SA(R)/SA(P))*NR*Ci
+const (
+	bvhLeafThreshold = 4
+	bvhNumBuckets    = 16
+	bvhTraversalCost = 1.0
+	bvhIntersectCost = 1.0
+)
+
+type bvhBucket struct {
+	count  int
+	bounds AABB
+	filled bool
+}
+
+// bvhNode is a node of a BVH: either a leaf holding items directly, or an
+// interior node with two children and no items of its own.
+type bvhNode struct {
+	bounds      AABB
+	left, right *bvhNode
+	items       []Item
+}
+
+// BVH accelerates ray intersection against a set of items by recursively
+// partitioning them into a binary tree of bounding boxes, built with the
+// surface-area heuristic.
+type BVH struct {
+	root *bvhNode
+}
+
+// NewBVH builds a BVH over items
+func NewBVH(items []Item) *BVH {
+	if len(items) == 0 {
+		return &BVH{}
+	}
+	cp := make([]Item, len(items))
+	copy(cp, items)
+	return &BVH{root: buildBVHNode(cp)}
+}
+
+// Bounds returns the axis-aligned bounding box enclosing the whole BVH
+func (bvh *BVH) Bounds() AABB {
+	if bvh.root == nil {
+		return AABB{}
+	}
+	return bvh.root.bounds
+}
+
+// Intersect finds the nearest item in the BVH that r intersects
+func (bvh *BVH) Intersect(r R3) (bool, Hit, int64) {
+	return bvh.IntersectBounded(r, math.Inf(1))
+}
+
+// IntersectBounded is Intersect, but ignores any hit with a ray parameter
+// greater than maxT. Callers that only care whether something blocks a
+// ray before a known point (e.g. Scene.shadowed, which only cares about
+// blockers nearer than the light) can use this to prune traversal instead
+// of always chasing the globally nearest hit.
+func (bvh *BVH) IntersectBounded(r R3, maxT float64) (bool, Hit, int64) {
+	if bvh.root == nil {
+		return false, Hit{}, 0
+	}
+	return bvh.root.intersect(r, maxT)
+}
+
+// intersect descends the closer child first, pruning the farther child
+// once its near bound is beyond the closest hit found so far.
+func (n *bvhNode) intersect(r R3, maxT float64) (bool, Hit, int64) {
+	tmin, _, ok := n.bounds.Intersect(r)
+	if !ok || tmin > maxT {
+		return false, Hit{}, 1
+	}
+
+	if n.items != nil {
+		var totalTests int64
+		found := false
+		var best Hit
+		bestT := maxT
+		for _, it := range n.items {
+			intersects, h, numTests := it.Intersect(r)
+			totalTests += numTests
+			if !intersects {
+				continue
+			}
+			if t := rayParam(r, h.At); t < bestT {
+				found, best, bestT = true, h, t
+			}
+		}
+		return found, best, totalTests
+	}
+
+	leftTmin, _, leftOk := n.left.bounds.Intersect(r)
+	rightTmin, _, rightOk := n.right.bounds.Intersect(r)
+
+	first, second := n.left, n.right
+	firstOk, secondOk := leftOk, rightOk
+	firstTmin, secondTmin := leftTmin, rightTmin
+	if rightOk && (!leftOk || rightTmin < leftTmin) {
+		first, second = n.right, n.left
+		firstOk, secondOk = rightOk, leftOk
+		firstTmin, secondTmin = rightTmin, leftTmin
+	}
+
+	var totalTests int64
+	found := false
+	var best Hit
+	bestT := maxT
+
+	if firstOk && firstTmin <= bestT {
+		hit, h, tests := first.intersect(r, bestT)
+		totalTests += tests
+		if hit {
+			found, best, bestT = true, h, rayParam(r, h.At)
+		}
+	}
+	if secondOk && secondTmin <= bestT {
+		hit, h, tests := second.intersect(r, bestT)
+		totalTests += tests
+		if hit {
+			found, best, bestT = true, h, rayParam(r, h.At)
+		}
+	}
+
+	return found, best, totalTests
+}
+
+func boundsOfItems(items []Item) AABB {
+	bounds := items[0].Bounds()
+	for _, it := range items[1:] {
+		bounds = unionAABB(bounds, it.Bounds())
+	}
+	return bounds
+}
+
+func boundsOfCentroids(items []Item) AABB {
+	pts := make([]P3, len(items))
+	for i, it := range items {
+		pts[i] = it.Bounds().centroid()
+	}
+	return boundsOfPoints(pts...)
+}
+
+// buildBVHNode recursively partitions items using a binned SAH split,
+// falling back to a leaf when no split beats the cost of testing every
+// item directly.
+func buildBVHNode(items []Item) *bvhNode {
+	bounds := boundsOfItems(items)
+	if len(items) <= bvhLeafThreshold {
+		return &bvhNode{bounds: bounds, items: items}
+	}
+
+	centroidBounds := boundsOfCentroids(items)
+	axis := centroidBounds.longestAxis()
+	lo, hi := axisVal(centroidBounds.Min, axis), axisVal(centroidBounds.Max, axis)
+	if hi-lo < Epsilon {
+		// All centroids coincide on the widest axis: no split can help.
+		return &bvhNode{bounds: bounds, items: items}
+	}
+
+	bucketOf := func(it Item) int {
+		c := axisVal(it.Bounds().centroid(), axis)
+		b := int(bvhNumBuckets * (c - lo) / (hi - lo))
+		if b < 0 {
+			b = 0
+		}
+		if b >= bvhNumBuckets {
+			b = bvhNumBuckets - 1
+		}
+		return b
+	}
+
+	var buckets [bvhNumBuckets]bvhBucket
+	for _, it := range items {
+		b := &buckets[bucketOf(it)]
+		b.count++
+		if !b.filled {
+			b.bounds, b.filled = it.Bounds(), true
+		} else {
+			b.bounds = unionAABB(b.bounds, it.Bounds())
+		}
+	}
+
+	parentSA := bounds.surfaceArea()
+	bestCost, bestSplit := math.Inf(1), -1
+	for split := 0; split < bvhNumBuckets-1; split++ {
+		var left, right AABB
+		leftFilled, rightFilled := false, false
+		var nl, nr int
+		for i := 0; i <= split; i++ {
+			if buckets[i].count == 0 {
+				continue
+			}
+			nl += buckets[i].count
+			if !leftFilled {
+				left, leftFilled = buckets[i].bounds, true
+			} else {
+				left = unionAABB(left, buckets[i].bounds)
+			}
+		}
+		for i := split + 1; i < bvhNumBuckets; i++ {
+			if buckets[i].count == 0 {
+				continue
+			}
+			nr += buckets[i].count
+			if !rightFilled {
+				right, rightFilled = buckets[i].bounds, true
+			} else {
+				right = unionAABB(right, buckets[i].bounds)
+			}
+		}
+		if nl == 0 || nr == 0 {
+			continue
+		}
+		cost := bvhTraversalCost +
+			(left.surfaceArea()/parentSA)*float64(nl)*bvhIntersectCost +
+			(right.surfaceArea()/parentSA)*float64(nr)*bvhIntersectCost
+		if cost < bestCost {
+			bestCost, bestSplit = cost, split
+		}
+	}
+
+	leafCost := bvhIntersectCost * float64(len(items))
+	if bestSplit < 0 || bestCost >= leafCost {
+		return &bvhNode{bounds: bounds, items: items}
+	}
+
+	var left, right []Item
+	for _, it := range items {
+		if bucketOf(it) <= bestSplit {
+			left = append(left, it)
+		} else {
+			right = append(right, it)
+		}
+	}
+	if len(left) == 0 || len(right) == 0 {
+		// Degenerate centroid distribution: fall back to a leaf rather
+		// than recursing forever.
+		return &bvhNode{bounds: bounds, items: items}
+	}
+
+	return &bvhNode{
+		bounds: bounds,
+		left:   buildBVHNode(left),
+		right:  buildBVHNode(right),
+	}
+}
@@ -0,0 +1,71 @@
+package rt
+
+import "testing"
+
+// spheresAlongX returns n unit spheres spaced well apart along the X axis,
+// so a BVH over them is forced to split rather than fall back to a single
+// leaf (see bvhLeafThreshold).
+func spheresAlongX(n int) []Item {
+	items := make([]Item, n)
+	for i := 0; i < n; i++ {
+		items[i] = Sphere{Centre: P3{X: float64(i) * 10}, Radius: 1}
+	}
+	return items
+}
+
+func TestNewBVHSplitsLargeItemSets(t *testing.T) {
+	items := spheresAlongX(32)
+	bvh := NewBVH(items)
+
+	if bvh.root.items != nil {
+		t.Fatalf("root should be an interior node, got a leaf with %d items", len(bvh.root.items))
+	}
+}
+
+func TestNewBVHLeafThreshold(t *testing.T) {
+	items := spheresAlongX(bvhLeafThreshold)
+	bvh := NewBVH(items)
+
+	if bvh.root.items == nil {
+		t.Fatalf("root should be a single leaf for %d items (<= bvhLeafThreshold)", len(items))
+	}
+	if len(bvh.root.items) != len(items) {
+		t.Fatalf("leaf should hold all %d items, got %d", len(items), len(bvh.root.items))
+	}
+}
+
+// TestBVHIntersectCostVsLinear checks that intersecting a BVH built over
+// many widely-spaced spheres costs far fewer Item.Intersect calls than a
+// linear scan of every sphere would, confirming the tree is actually
+// pruning rather than degenerating into one big leaf.
+func TestBVHIntersectCostVsLinear(t *testing.T) {
+	items := spheresAlongX(64)
+	bvh := NewBVH(items)
+
+	ray := R3{At: P3{X: 0, Y: 0, Z: -10}, Dir: P3{X: 0, Y: 0, Z: 1}}
+	hit, _, numTests := bvh.Intersect(ray)
+	if !hit {
+		t.Fatalf("expected ray to hit the sphere at the origin")
+	}
+	if numTests >= int64(len(items)) {
+		t.Fatalf("numTests = %d, want well under the linear-scan cost of %d", numTests, len(items))
+	}
+}
+
+func TestBVHIntersectMiss(t *testing.T) {
+	bvh := NewBVH(spheresAlongX(16))
+
+	ray := R3{At: P3{X: 0, Y: 100, Z: -10}, Dir: P3{X: 0, Y: 0, Z: 1}}
+	hit, _, _ := bvh.Intersect(ray)
+	if hit {
+		t.Fatalf("expected a ray well off every sphere to miss")
+	}
+}
+
+func TestNewBVHEmpty(t *testing.T) {
+	bvh := NewBVH(nil)
+	hit, _, numTests := bvh.Intersect(R3{At: P3{}, Dir: P3{X: 0, Y: 0, Z: 1}})
+	if hit || numTests != 0 {
+		t.Fatalf("empty BVH should never report a hit, got hit=%v numTests=%d", hit, numTests)
+	}
+}
@@ -0,0 +1,158 @@
+package rt
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// Material describes how a surface scatters an incoming ray for the
+// purposes of path tracing: given the incoming ray and the hit it
+// produced, Scatter returns the colour by which a bounced ray's
+// contribution should be attenuated, the scattered ray itself, and
+// whether the ray scatters at all (a light-absorbing surface returns
+// ok=false). rng is supplied by the caller (one per render worker) so
+// that concurrent renders don't contend on a single global source.
+type Material interface {
+	Scatter(rIn R3, hit Hit, rng *rand.Rand) (attenuation color.Color, scattered R3, ok bool)
+}
+
+// colourToUnit converts a color.Color to linear-ish [0,1] RGB components
+func colourToUnit(c color.Color) (r, g, b float64) {
+	cr, cg, cb, _ := c.RGBA()
+	return float64(cr) / 0xffff, float64(cg) / 0xffff, float64(cb) / 0xffff
+}
+
+// unitToColour converts [0,1] RGB components back to a color.Color
+func unitToColour(r, g, b float64) color.Color {
+	clamp := func(v float64) uint8 {
+		if v < 0 {
+			v = 0
+		}
+		if v > 1 {
+			v = 1
+		}
+		return uint8(v * 255)
+	}
+	return color.NRGBA{R: clamp(r), G: clamp(g), B: clamp(b), A: 255}
+}
+
+// materialColour returns a representative colour for a Material, used to
+// populate Hit.Colour for the direct-illumination renderer
+func materialColour(m Material) color.Color {
+	switch mat := m.(type) {
+	case Lambertian:
+		return mat.Albedo
+	case Metal:
+		return mat.Albedo
+	case Dielectric:
+		return color.White
+	default:
+		return color.White
+	}
+}
+
+// randomUnitVector returns a uniformly distributed unit vector
+func randomUnitVector(rng *rand.Rand) P3 {
+	for {
+		p := P3{
+			X: 2*rng.Float64() - 1,
+			Y: 2*rng.Float64() - 1,
+			Z: 2*rng.Float64() - 1,
+		}
+		if l := p.Len(); l > Epsilon && l <= 1 {
+			return p.Normalise()
+		}
+	}
+}
+
+// reflect reflects v about the surface normal n (n must be a unit vector)
+func reflect(v, n P3) P3 {
+	return v.Sub(n.Scale(2 * v.Dot(n)))
+}
+
+// refract bends v through a surface with normal n (pointing against v)
+// given the ratio of refractive indices etaOverEtaPrime, following
+// Snell's law
+func refract(v, n P3, etaOverEtaPrime float64) P3 {
+	cosTheta := math.Min(v.Scale(-1).Dot(n), 1)
+	rOutPerp := v.Add(n.Scale(cosTheta)).Scale(etaOverEtaPrime)
+	rOutParallel := n.Scale(-math.Sqrt(math.Abs(1 - rOutPerp.Dot(rOutPerp))))
+	return rOutPerp.Add(rOutParallel)
+}
+
+// schlick is Schlick's approximation to the Fresnel reflectance at the
+// given cosine of the incidence angle and refractive index ratio
+func schlick(cosine, refIdx float64) float64 {
+	r0 := (1 - refIdx) / (1 + refIdx)
+	r0 = r0 * r0
+	return r0 + (1-r0)*math.Pow(1-cosine, 5)
+}
+
+// Lambertian is a matte, diffuse material: it scatters towards a random
+// point on the unit sphere offset from the hit normal
+type Lambertian struct {
+	Albedo color.Color
+}
+
+// Scatter implements Material
+func (l Lambertian) Scatter(rIn R3, hit Hit, rng *rand.Rand) (color.Color, R3, bool) {
+	dir := hit.Normal.Normalise().Add(randomUnitVector(rng))
+	if dir.Len() < Epsilon {
+		dir = hit.Normal.Normalise()
+	}
+	return l.Albedo, R3{At: hit.At, Dir: dir}, true
+}
+
+// Metal is a reflective material: Fuzz randomises the reflected
+// direction within a sphere of that radius to produce brushed/rough
+// reflections, with 0 giving a perfect mirror
+type Metal struct {
+	Albedo color.Color
+	Fuzz   float64
+}
+
+// Scatter implements Material
+func (m Metal) Scatter(rIn R3, hit Hit, rng *rand.Rand) (color.Color, R3, bool) {
+	reflected := reflect(rIn.Dir.Normalise(), hit.Normal.Normalise())
+	dir := reflected.Add(randomUnitVector(rng).Scale(m.Fuzz))
+	if dir.Dot(hit.Normal) <= 0 {
+		// Fuzzing sent the ray below the surface: absorb it.
+		return color.Black, R3{}, false
+	}
+	return m.Albedo, R3{At: hit.At, Dir: dir}, true
+}
+
+// Dielectric is a clear refractive material such as glass or water,
+// with IOR its index of refraction (relative to a vacuum)
+type Dielectric struct {
+	IOR float64
+}
+
+// Scatter implements Material
+func (d Dielectric) Scatter(rIn R3, hit Hit, rng *rand.Rand) (color.Color, R3, bool) {
+	normal := hit.Normal.Normalise()
+	dir := rIn.Dir.Normalise()
+
+	// Assume the ray is entering the material if it opposes the
+	// (outward-facing) normal, and exiting it otherwise.
+	front := dir.Dot(normal) < 0
+	refRatio := d.IOR
+	if front {
+		refRatio = 1 / d.IOR
+	} else {
+		normal = normal.Scale(-1)
+	}
+
+	cosTheta := math.Min(dir.Scale(-1).Dot(normal), 1)
+	sinTheta := math.Sqrt(1 - cosTheta*cosTheta)
+
+	var outDir P3
+	if refRatio*sinTheta > 1 || schlick(cosTheta, refRatio) > rng.Float64() {
+		outDir = reflect(dir, normal)
+	} else {
+		outDir = refract(dir, normal, refRatio)
+	}
+
+	return color.White, R3{At: hit.At, Dir: outDir}, true
+}
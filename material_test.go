@@ -0,0 +1,139 @@
+package rt
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestReflect(t *testing.T) {
+	v := P3{X: 1, Y: -1, Z: 0}
+	n := P3{X: 0, Y: 1, Z: 0}
+	got := reflect(v, n)
+	want := P3{X: 1, Y: 1, Z: 0}
+	if math.Abs(got.X-want.X) > Epsilon || math.Abs(got.Y-want.Y) > Epsilon || math.Abs(got.Z-want.Z) > Epsilon {
+		t.Fatalf("reflect(%+v, %+v) = %+v, want %+v", v, n, got, want)
+	}
+}
+
+func TestSchlickNormalIncidence(t *testing.T) {
+	refIdx := 1.5
+	r0 := (1 - refIdx) / (1 + refIdx)
+	r0 *= r0
+	if got := schlick(1, refIdx); math.Abs(got-r0) > Epsilon {
+		t.Fatalf("schlick(1, %v) = %v, want %v (r0)", refIdx, got, r0)
+	}
+}
+
+func TestRefractPreservesDirectionAtEqualIOR(t *testing.T) {
+	v := P3{X: 0, Y: 0, Z: 1}
+	n := P3{X: 0, Y: 0, Z: -1}
+	got := refract(v, n, 1.0)
+	if math.Abs(got.X) > Epsilon || math.Abs(got.Y) > Epsilon || math.Abs(got.Z-1) > Epsilon {
+		t.Fatalf("refract with equal indices = %+v, want the ray unchanged (%+v)", got, v)
+	}
+}
+
+func TestLambertianScatter(t *testing.T) {
+	albedo := color.NRGBA{R: 100, G: 150, B: 200, A: 255}
+	l := Lambertian{Albedo: albedo}
+	hit := Hit{At: P3{X: 0, Y: 0, Z: 0}, Normal: P3{X: 0, Y: 0, Z: 1}}
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 100; i++ {
+		attenuation, scattered, ok := l.Scatter(R3{}, hit, rng)
+		if !ok {
+			t.Fatalf("Lambertian should never absorb")
+		}
+		if attenuation != color.Color(albedo) {
+			t.Fatalf("attenuation = %+v, want the albedo %+v", attenuation, albedo)
+		}
+		if scattered.Dir.Dot(hit.Normal) < 0 {
+			t.Fatalf("scattered direction %+v points below the surface", scattered.Dir)
+		}
+	}
+}
+
+func TestMetalMirrorReflectionAtZeroFuzz(t *testing.T) {
+	m := Metal{Albedo: color.White, Fuzz: 0}
+	hit := Hit{Normal: P3{X: 0, Y: 0, Z: 1}}
+	rIn := R3{Dir: P3{X: 1, Y: 0, Z: -1}}
+	rng := rand.New(rand.NewSource(1))
+
+	_, scattered, ok := m.Scatter(rIn, hit, rng)
+	if !ok {
+		t.Fatalf("a perfect mirror should never absorb")
+	}
+	want := reflect(rIn.Dir.Normalise(), hit.Normal)
+	if math.Abs(scattered.Dir.X-want.X) > Epsilon || math.Abs(scattered.Dir.Z-want.Z) > Epsilon {
+		t.Fatalf("Metal with Fuzz=0 scattered to %+v, want the exact mirror reflection %+v", scattered.Dir, want)
+	}
+}
+
+func TestMetalAbsorbsWhenFuzzPushesBelowSurface(t *testing.T) {
+	// A grazing reflection (barely above the surface) plus a large fuzz
+	// radius: across enough seeds, some fraction of the randomised
+	// directions must land below the surface and be absorbed.
+	m := Metal{Albedo: color.White, Fuzz: 1}
+	hit := Hit{Normal: P3{X: 0, Y: 0, Z: 1}}
+	rIn := R3{Dir: P3{X: 1, Y: 0, Z: -0.01}}
+
+	sawAbsorbed, sawScattered := false, false
+	for seed := int64(0); seed < 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		attenuation, scattered, ok := m.Scatter(rIn, hit, rng)
+		if !ok {
+			sawAbsorbed = true
+			if attenuation != color.Color(color.Black) {
+				t.Fatalf("absorbed ray should attenuate to black, got %+v", attenuation)
+			}
+			continue
+		}
+		sawScattered = true
+		if scattered.Dir.Dot(hit.Normal) <= 0 {
+			t.Fatalf("returned ok=true but scattered direction %+v is at/below the surface", scattered.Dir)
+		}
+	}
+	if !sawAbsorbed {
+		t.Fatalf("expected at least one fuzzed reflection to be absorbed")
+	}
+	if !sawScattered {
+		t.Fatalf("expected at least one fuzzed reflection to survive")
+	}
+}
+
+func TestDielectricTotalInternalReflection(t *testing.T) {
+	d := Dielectric{IOR: 1.5}
+	normal := P3{X: 0, Y: 0, Z: 1}
+	// Travelling from inside the glass towards the surface at a steep
+	// angle (80 degrees from the normal), well past the ~41.8 degree
+	// critical angle for IOR 1.5, so this must always reflect regardless
+	// of rng.
+	angle := 80.0 * math.Pi / 180.0
+	dir := P3{X: math.Sin(angle), Y: 0, Z: math.Cos(angle)}
+	hit := Hit{Normal: normal}
+	rng := rand.New(rand.NewSource(1))
+
+	_, scattered, ok := d.Scatter(R3{Dir: dir}, hit, rng)
+	if !ok {
+		t.Fatalf("Dielectric should never absorb")
+	}
+	want := reflect(dir, normal)
+	if math.Abs(scattered.Dir.X-want.X) > Epsilon || math.Abs(scattered.Dir.Z-want.Z) > Epsilon {
+		t.Fatalf("total internal reflection gave %+v, want the mirror reflection %+v", scattered.Dir, want)
+	}
+}
+
+func TestDielectricNeverAbsorbs(t *testing.T) {
+	d := Dielectric{IOR: 1.5}
+	hit := Hit{Normal: P3{X: 0, Y: 0, Z: 1}}
+	rIn := R3{Dir: P3{X: 0, Y: 0, Z: -1}}
+
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		if _, _, ok := d.Scatter(rIn, hit, rng); !ok {
+			t.Fatalf("Dielectric.Scatter returned ok=false, it should always scatter")
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package rt
+
+import (
+	"bufio"
+	"fmt"
+	"image/color"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Mesh is a collection of triangles sharing a vertex (and optional normal)
+// buffer, typically loaded with LoadOBJ.
+type Mesh struct {
+	CompositeItem
+
+	Vertices []P3
+	Normals  []P3
+	Material Material
+}
+
+// meshTri is a single triangular face of a Mesh, referencing the mesh's
+// shared vertex buffer by index rather than storing its own points.
+type meshTri struct {
+	mesh         *Mesh
+	ia, ib, ic   int
+	na, nb, nc   int
+	smoothShaded bool
+}
+
+func (mt meshTri) t3() T3 {
+	return T3{A: mt.mesh.Vertices[mt.ia], B: mt.mesh.Vertices[mt.ib], C: mt.mesh.Vertices[mt.ic]}
+}
+
+// Intersect returns whether a ray intersects this face
+func (mt meshTri) Intersect(r R3) (bool, Hit, int64) {
+	t3 := mt.t3()
+	hit, u, v, p := t3.IntersectUV(r)
+	if !hit {
+		return false, Hit{}, 1
+	}
+
+	normal := t3.normal()
+	if mt.smoothShaded {
+		w := 1 - u - v
+		na := mt.mesh.Normals[mt.na]
+		nb := mt.mesh.Normals[mt.nb]
+		nc := mt.mesh.Normals[mt.nc]
+		normal = na.Scale(u).Add(nb.Scale(v)).Add(nc.Scale(w)).Normalise()
+	}
+
+	return true, Hit{p, normal, materialColour(mt.mesh.Material), mt.mesh.Material}, 1
+}
+
+// Bounds returns the axis-aligned bounding box of this face
+func (mt meshTri) Bounds() AABB {
+	return boundsOfPoints(mt.mesh.Vertices[mt.ia], mt.mesh.Vertices[mt.ib], mt.mesh.Vertices[mt.ic])
+}
+
+// objFaceVertex is one `f` line's per-vertex reference: a vertex index and,
+// if present, a normal index (both already resolved to 0-based).
+type objFaceVertex struct {
+	v, n    int
+	hasNorm bool
+}
+
+// LoadOBJ parses a Wavefront OBJ file at path into a Mesh of triangles
+// sharing a single vertex buffer. It understands `v` and `vn` lines and
+// triangulates `f` lines (which may reference more than 3 vertices) via
+// fan triangulation. Face indices may be given as `v`, `v/vt`, or `v/vt/vn`,
+// are 1-based, and may be negative to reference relative to the current
+// end of the vertex/normal list.
+func LoadOBJ(path string) (*Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening obj file %q: %w", path, err)
+	}
+	defer f.Close()
+
+	m := &Mesh{Material: Lambertian{Albedo: color.NRGBA{R: 200, G: 200, B: 200, A: 255}}}
+
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+
+		switch fields[0] {
+		case "v":
+			p, err := parseP3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: bad vertex: %w", path, lineNum, err)
+			}
+			m.Vertices = append(m.Vertices, p)
+
+		case "vn":
+			n, err := parseP3(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: bad normal: %w", path, lineNum, err)
+			}
+			m.Normals = append(m.Normals, n)
+
+		case "f":
+			if err := m.addFace(fields[1:]); err != nil {
+				return nil, fmt.Errorf("%s:%d: bad face: %w", path, lineNum, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading obj file %q: %w", path, err)
+	}
+
+	return m, nil
+}
+
+func parseP3(fields []string) (P3, error) {
+	if len(fields) < 3 {
+		return P3{}, fmt.Errorf("expected 3 co-ordinates, got %d", len(fields))
+	}
+	var v [3]float64
+	for i := 0; i < 3; i++ {
+		f, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return P3{}, err
+		}
+		v[i] = f
+	}
+	return P3{X: v[0], Y: v[1], Z: v[2]}, nil
+}
+
+// addFace triangulates an `f` line (fan triangulation from its first
+// vertex) and appends the resulting meshTri items.
+func (m *Mesh) addFace(tokens []string) error {
+	verts := make([]objFaceVertex, len(tokens))
+	for i, tok := range tokens {
+		fv, err := parseFaceVertex(tok, len(m.Vertices), len(m.Normals))
+		if err != nil {
+			return err
+		}
+		verts[i] = fv
+	}
+	if len(verts) < 3 {
+		return fmt.Errorf("face has only %d vertices", len(verts))
+	}
+
+	for i := 1; i < len(verts)-1; i++ {
+		a, b, c := verts[0], verts[i], verts[i+1]
+		smooth := a.hasNorm && b.hasNorm && c.hasNorm
+		m.children = append(m.children, meshTri{
+			mesh:         m,
+			ia:           a.v,
+			ib:           b.v,
+			ic:           c.v,
+			na:           a.n,
+			nb:           b.n,
+			nc:           c.n,
+			smoothShaded: smooth,
+		})
+	}
+	return nil
+}
+
+// parseFaceVertex parses a single `v`, `v/vt`, or `v/vt/vn` face reference,
+// resolving 1-based and negative indices to 0-based ones and validating
+// them against the vertex/normal data parsed so far.
+func parseFaceVertex(tok string, numVerts, numNormals int) (objFaceVertex, error) {
+	parts := strings.Split(tok, "/")
+
+	v, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return objFaceVertex{}, fmt.Errorf("bad vertex index %q: %w", parts[0], err)
+	}
+	vi, err := resolveIndex(v, numVerts)
+	if err != nil {
+		return objFaceVertex{}, fmt.Errorf("vertex index %q: %w", parts[0], err)
+	}
+	fv := objFaceVertex{v: vi}
+
+	if len(parts) == 3 && parts[2] != "" {
+		n, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return objFaceVertex{}, fmt.Errorf("bad normal index %q: %w", parts[2], err)
+		}
+		ni, err := resolveIndex(n, numNormals)
+		if err != nil {
+			return objFaceVertex{}, fmt.Errorf("normal index %q: %w", parts[2], err)
+		}
+		fv.n = ni
+		fv.hasNorm = true
+	}
+
+	return fv, nil
+}
+
+// resolveIndex turns a 1-based OBJ index (or a negative index counting
+// back from the current end of the list) into a 0-based index, and
+// validates that it actually lands within [0, length) — catching a 0
+// index, a forward reference past what's been parsed so far, or a
+// negative index that underflows, at parse time rather than as an
+// out-of-bounds panic later during rendering.
+func resolveIndex(idx, length int) (int, error) {
+	if idx == 0 {
+		return 0, fmt.Errorf("index 0 is invalid (OBJ indices are 1-based)")
+	}
+
+	resolved := idx - 1
+	if idx < 0 {
+		resolved = length + idx
+	}
+
+	if resolved < 0 || resolved >= length {
+		return 0, fmt.Errorf("index %d out of range (have %d so far)", idx, length)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,84 @@
+package rt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeOBJ(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mesh.obj")
+	if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+		t.Fatalf("writing test obj: %v", err)
+	}
+	return path
+}
+
+func TestLoadOBJTriangulatesNGon(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+f 1 2 3 4
+`)
+	m, err := LoadOBJ(path)
+	if err != nil {
+		t.Fatalf("LoadOBJ: %v", err)
+	}
+	if len(m.children) != 2 {
+		t.Fatalf("expected a quad to fan-triangulate into 2 triangles, got %d", len(m.children))
+	}
+}
+
+func TestLoadOBJNegativeIndices(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 1 1 0
+f -3 -2 -1
+`)
+	m, err := LoadOBJ(path)
+	if err != nil {
+		t.Fatalf("LoadOBJ: %v", err)
+	}
+	tri := m.children[0].(meshTri)
+	if tri.ia != 0 || tri.ib != 1 || tri.ic != 2 {
+		t.Fatalf("negative indices resolved to (%d,%d,%d), want (0,1,2)", tri.ia, tri.ib, tri.ic)
+	}
+}
+
+func TestLoadOBJZeroIndexIsInvalid(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+v 1 1 0
+f 0 1 2
+`)
+	if _, err := LoadOBJ(path); err == nil {
+		t.Fatalf("expected an error for a 0 face index")
+	}
+}
+
+func TestLoadOBJForwardReferenceIsInvalid(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+f 1 2 3
+`)
+	if _, err := LoadOBJ(path); err == nil {
+		t.Fatalf("expected an error for a face index beyond the vertices parsed so far")
+	}
+}
+
+func TestLoadOBJNegativeIndexUnderflowIsInvalid(t *testing.T) {
+	path := writeOBJ(t, `
+v 0 0 0
+v 1 0 0
+f -5 1 2
+`)
+	if _, err := LoadOBJ(path); err == nil {
+		t.Fatalf("expected an error for a negative index underflowing before the first vertex")
+	}
+}
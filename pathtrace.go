@@ -0,0 +1,137 @@
+package rt
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+)
+
+// russianRouletteDepth is the bounce count after which paths are
+// stochastically terminated rather than traced indefinitely
+const russianRouletteDepth = 3
+
+// PathTrace renders the pixel at (x, y) by jittering samplesPerPixel rays
+// across the pixel, following each one's bounces up to maxDepth, and
+// averaging the results. Paths are terminated early via Russian roulette
+// once they've bounced russianRouletteDepth times. The result is gamma-2.0
+// encoded (i.e. linear light raised to the power 1/2). rng supplies all
+// randomness, so callers that render concurrently (see RenderImage) should
+// give each worker its own.
+func (s *Scene) PathTrace(x, y float64, samplesPerPixel, maxDepth int, rng *rand.Rand) color.Color {
+	s.ensureBVH()
+
+	var sum P3
+	for i := 0; i < samplesPerPixel; i++ {
+		jx := x + (rng.Float64()-0.5)*s.pixelDX
+		jy := y + (rng.Float64()-0.5)*s.pixelDY
+		ray := R3{
+			At:  P3{X: 0, Y: 0, Z: s.viewerDist},
+			Dir: P3{X: jx / s.screenDist, Y: jy / s.screenDist, Z: 1.0},
+		}
+		sum = sum.Add(s.traceRay(ray, maxDepth, rng))
+	}
+
+	n := float64(samplesPerPixel)
+	return linearToGamma(sum.Scale(1 / n))
+}
+
+// traceRay follows a single path through the scene, accumulating the
+// attenuation of each bounce's Material into a running throughput (reusing
+// P3 as an RGB triple). At each bounce it also samples s.lights directly
+// (next-event estimation, the path-traced counterpart of the shadow rays
+// in Scene.illumination) since none of our materials are emissive and
+// s.ambient is black: without this, every path either keeps bouncing
+// towards the black background or gets Russian-roulette-killed, and the
+// tracer can never pick up any light at all.
+func (s *Scene) traceRay(ray R3, maxDepth int, rng *rand.Rand) P3 {
+	throughput := P3{X: 1, Y: 1, Z: 1}
+	var radiance P3
+	current := ray
+
+	for depth := 0; depth < maxDepth; depth++ {
+		intersects, hit, _ := s.Intersect(current)
+		if !intersects {
+			return radiance.Add(attenuate(throughput, s.ambient(current)))
+		}
+		if hit.Material == nil {
+			return radiance
+		}
+
+		radiance = radiance.Add(mulRGB(throughput, s.directLight(hit)))
+
+		attenuation, scattered, ok := hit.Material.Scatter(current, hit, rng)
+		if !ok {
+			return radiance
+		}
+		throughput = attenuate(throughput, attenuation)
+
+		if depth >= russianRouletteDepth {
+			survival := math.Max(throughput.X, math.Max(throughput.Y, throughput.Z))
+			if survival <= 0 || rng.Float64() > survival {
+				return radiance
+			}
+			throughput = throughput.Scale(1 / survival)
+		}
+
+		current = scattered
+	}
+
+	// Exceeded maxDepth: whatever radiance we've already gathered from
+	// earlier bounces stands, we just stop following this path further.
+	return radiance
+}
+
+// directLight sums the Lambert-weighted, shadow-tested contribution of
+// every light in the scene at hit, mirroring Scene.illumination but
+// returning a linear RGB triple (as a P3) rather than a clamped
+// color.Color.
+func (s *Scene) directLight(hit Hit) P3 {
+	surfR, surfG, surfB := colourToUnit(hit.Colour)
+	normal := hit.Normal.Normalise()
+
+	var sum P3
+	for _, light := range s.lights {
+		toLight := light.At.Sub(hit.At)
+		distToLight := toLight.Len()
+		dir := toLight.Normalise()
+
+		ndotl := normal.Dot(dir)
+		if ndotl <= 0 {
+			continue
+		}
+
+		shadowRay := R3{At: hit.At.Add(normal.Scale(Epsilon)), Dir: dir}
+		if s.shadowed(shadowRay, distToLight) {
+			continue
+		}
+
+		lightR, lightG, lightB := colourToUnit(light.Colour)
+		sum.X += ndotl * lightR * surfR
+		sum.Y += ndotl * lightG * surfG
+		sum.Z += ndotl * lightB * surfB
+	}
+	return sum
+}
+
+// attenuate multiplies an RGB throughput (as a P3) by a colour, channel by channel
+func attenuate(throughput P3, c color.Color) P3 {
+	r, g, b := colourToUnit(c)
+	return mulRGB(throughput, P3{X: r, Y: g, Z: b})
+}
+
+// mulRGB multiplies two RGB triples (each reusing P3) channel by channel
+func mulRGB(a, b P3) P3 {
+	return P3{X: a.X * b.X, Y: a.Y * b.Y, Z: a.Z * b.Z}
+}
+
+// linearToGamma converts a linear-light RGB triple (as a P3) to a
+// gamma-2.0-encoded color.Color
+func linearToGamma(rgb P3) color.Color {
+	gamma := func(v float64) float64 {
+		if v < 0 {
+			v = 0
+		}
+		return math.Sqrt(v)
+	}
+	return unitToColour(gamma(rgb.X), gamma(rgb.Y), gamma(rgb.Z))
+}
@@ -0,0 +1,49 @@
+package rt
+
+import (
+	"image/color"
+	"math/rand"
+	"testing"
+)
+
+func litLambertianScene() *Scene {
+	s := New(5, 1)
+	s.AddItem(Sphere{Centre: P3{X: 0, Y: 0, Z: 10}, Radius: 2, Material: Lambertian{Albedo: color.White}})
+	s.AddLight(Light{At: P3{X: 5, Y: 5, Z: 0}, Colour: color.White})
+	return s
+}
+
+func TestTraceRayAccumulatesRadianceFromLights(t *testing.T) {
+	s := litLambertianScene()
+	ray := R3{At: P3{X: 0, Y: 0, Z: 0}, Dir: P3{X: 0, Y: 0, Z: 1}}
+	rng := rand.New(rand.NewSource(1))
+
+	radiance := s.traceRay(ray, 8, rng)
+	if radiance.X == 0 && radiance.Y == 0 && radiance.Z == 0 {
+		t.Fatalf("expected a lit Lambertian sphere to accumulate non-zero radiance, got %+v", radiance)
+	}
+}
+
+func TestTraceRayNoLightsIsBlack(t *testing.T) {
+	s := New(5, 1)
+	s.AddItem(Sphere{Centre: P3{X: 0, Y: 0, Z: 10}, Radius: 2, Material: Lambertian{Albedo: color.White}})
+	ray := R3{At: P3{X: 0, Y: 0, Z: 0}, Dir: P3{X: 0, Y: 0, Z: 1}}
+	rng := rand.New(rand.NewSource(1))
+
+	radiance := s.traceRay(ray, 8, rng)
+	if radiance.X != 0 || radiance.Y != 0 || radiance.Z != 0 {
+		t.Fatalf("expected a scene with no lights to stay black, got %+v", radiance)
+	}
+}
+
+func TestPathTraceOfLitSceneIsNotBlack(t *testing.T) {
+	s := litLambertianScene()
+	s.SetPixelSize(0.01, 0.01)
+	rng := rand.New(rand.NewSource(1))
+
+	c := s.PathTrace(0, 0, 8, 8, rng)
+	r, g, b := colourToUnit(c)
+	if r == 0 && g == 0 && b == 0 {
+		t.Fatalf("PathTrace of a lit scene returned black")
+	}
+}
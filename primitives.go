@@ -0,0 +1,83 @@
+package rt
+
+import "math"
+
+// Sphere is a sphere with an analytic (as opposed to tessellated)
+// intersection test
+type Sphere struct {
+	Centre   P3
+	Radius   float64
+	Material Material
+}
+
+// Intersect solves the quadratic a*t^2 + 2*b*t + c = 0 for the ray/sphere
+// intersection, where a = D.D, b = oc.D and c = oc.oc - r^2 (oc being the
+// vector from the sphere's centre to the ray's origin), and returns the
+// smallest positive root.
+func (s Sphere) Intersect(r R3) (bool, Hit, int64) {
+	oc := r.At.Sub(s.Centre)
+	a := r.Dir.Dot(r.Dir)
+	b := oc.Dot(r.Dir)
+	c := oc.Dot(oc) - s.Radius*s.Radius
+
+	discriminant := b*b - a*c
+	if discriminant < 0 {
+		return false, Hit{}, 1
+	}
+	sqrtD := math.Sqrt(discriminant)
+
+	t := (-b - sqrtD) / a
+	if t < Epsilon {
+		t = (-b + sqrtD) / a
+	}
+	if t < Epsilon {
+		return false, Hit{}, 1
+	}
+
+	p := r.At.Add(r.Dir.Scale(t))
+	normal := p.Sub(s.Centre).Scale(1 / s.Radius)
+	return true, Hit{p, normal, materialColour(s.Material), s.Material}, 1
+}
+
+// Bounds returns the axis-aligned bounding box of this sphere
+func (s Sphere) Bounds() AABB {
+	rad := P3{X: s.Radius, Y: s.Radius, Z: s.Radius}
+	return AABB{Min: s.Centre.Sub(rad), Max: s.Centre.Add(rad)}
+}
+
+// planeBoundsExtent bounds an (infinite) Plane's AABB to a large but
+// finite cube, so BVH surface-area calculations don't have to deal with
+// infinities.
+const planeBoundsExtent = 1e6
+
+// Plane is an infinite flat plane through Point, perpendicular to Normal
+type Plane struct {
+	Point    P3
+	Normal   P3
+	Material Material
+}
+
+// Intersect finds where r crosses the plane
+func (pl Plane) Intersect(r R3) (bool, Hit, int64) {
+	normal := pl.Normal.Normalise()
+	denom := r.Dir.Dot(normal)
+	if math.Abs(denom) < Epsilon {
+		// Parallel to the plane
+		return false, Hit{}, 1
+	}
+
+	t := pl.Point.Sub(r.At).Dot(normal) / denom
+	if t < Epsilon {
+		return false, Hit{}, 1
+	}
+
+	p := r.At.Add(r.Dir.Scale(t))
+	return true, Hit{p, normal, materialColour(pl.Material), pl.Material}, 1
+}
+
+// Bounds returns a large, finite bounding box standing in for the plane's
+// actual infinite extent
+func (pl Plane) Bounds() AABB {
+	extent := P3{X: planeBoundsExtent, Y: planeBoundsExtent, Z: planeBoundsExtent}
+	return AABB{Min: pl.Point.Sub(extent), Max: pl.Point.Add(extent)}
+}
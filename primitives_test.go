@@ -0,0 +1,99 @@
+package rt
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSphereIntersectThroughCentre(t *testing.T) {
+	s := Sphere{Centre: P3{X: 0, Y: 0, Z: 10}, Radius: 2}
+	ray := R3{At: P3{X: 0, Y: 0, Z: 0}, Dir: P3{X: 0, Y: 0, Z: 1}}
+
+	hit, h, _ := s.Intersect(ray)
+	if !hit {
+		t.Fatalf("expected a ray through the centre to hit")
+	}
+	want := P3{X: 0, Y: 0, Z: 8}
+	if math.Abs(h.At.X-want.X) > Epsilon || math.Abs(h.At.Y-want.Y) > Epsilon || math.Abs(h.At.Z-want.Z) > Epsilon {
+		t.Fatalf("hit at %+v, want %+v (the near face)", h.At, want)
+	}
+}
+
+func TestSphereIntersectMiss(t *testing.T) {
+	s := Sphere{Centre: P3{X: 0, Y: 10, Z: 10}, Radius: 1}
+	ray := R3{At: P3{X: 0, Y: 0, Z: 0}, Dir: P3{X: 0, Y: 0, Z: 1}}
+
+	if hit, _, _ := s.Intersect(ray); hit {
+		t.Fatalf("expected a ray well away from the sphere to miss")
+	}
+}
+
+func TestSphereIntersectOriginInside(t *testing.T) {
+	s := Sphere{Centre: P3{X: 0, Y: 0, Z: 0}, Radius: 5}
+	ray := R3{At: P3{X: 0, Y: 0, Z: 0}, Dir: P3{X: 0, Y: 0, Z: 1}}
+
+	hit, h, _ := s.Intersect(ray)
+	if !hit {
+		t.Fatalf("expected a ray from inside the sphere to still hit (the far wall)")
+	}
+	if math.Abs(h.At.Z-5) > Epsilon {
+		t.Fatalf("hit at z=%v, want z=5 (exiting through the far wall)", h.At.Z)
+	}
+}
+
+func TestSphereIntersectTangent(t *testing.T) {
+	radius := 2.0
+	s := Sphere{Centre: P3{X: 0, Y: 0, Z: 10}, Radius: radius}
+	// A ray grazing the sphere: displaced by exactly radius on X, and thus
+	// touching at a single point where the discriminant is ~0.
+	ray := R3{At: P3{X: radius, Y: 0, Z: 0}, Dir: P3{X: 0, Y: 0, Z: 1}}
+
+	hit, h, _ := s.Intersect(ray)
+	if !hit {
+		t.Fatalf("expected a tangent ray to register a (grazing) hit")
+	}
+	want := P3{X: radius, Y: 0, Z: 10}
+	if math.Abs(h.At.X-want.X) > 1e-4 || math.Abs(h.At.Z-want.Z) > 1e-4 {
+		t.Fatalf("tangent hit at %+v, want %+v", h.At, want)
+	}
+}
+
+func TestSphereBounds(t *testing.T) {
+	s := Sphere{Centre: P3{X: 1, Y: 2, Z: 3}, Radius: 2}
+	b := s.Bounds()
+	if b.Min != (P3{X: -1, Y: 0, Z: 1}) || b.Max != (P3{X: 3, Y: 4, Z: 5}) {
+		t.Fatalf("Bounds() = %+v, want Min={-1 0 1} Max={3 4 5}", b)
+	}
+}
+
+func TestPlaneIntersectHit(t *testing.T) {
+	pl := Plane{Point: P3{X: 0, Y: 0, Z: 0}, Normal: P3{X: 0, Y: 1, Z: 0}}
+	ray := R3{At: P3{X: 0, Y: 5, Z: 0}, Dir: P3{X: 0, Y: -1, Z: 0}}
+
+	hit, h, _ := pl.Intersect(ray)
+	if !hit {
+		t.Fatalf("expected a ray towards the plane to hit")
+	}
+	if math.Abs(h.At.Y) > Epsilon {
+		t.Fatalf("hit at y=%v, want y=0", h.At.Y)
+	}
+}
+
+func TestPlaneIntersectBehindRay(t *testing.T) {
+	pl := Plane{Point: P3{X: 0, Y: 0, Z: 0}, Normal: P3{X: 0, Y: 1, Z: 0}}
+	// The plane is behind the ray's origin, travelling away from it.
+	ray := R3{At: P3{X: 0, Y: 5, Z: 0}, Dir: P3{X: 0, Y: 1, Z: 0}}
+
+	if hit, _, _ := pl.Intersect(ray); hit {
+		t.Fatalf("expected a ray travelling away from the plane to miss")
+	}
+}
+
+func TestPlaneIntersectParallel(t *testing.T) {
+	pl := Plane{Point: P3{X: 0, Y: 0, Z: 0}, Normal: P3{X: 0, Y: 1, Z: 0}}
+	ray := R3{At: P3{X: 0, Y: 5, Z: 0}, Dir: P3{X: 1, Y: 0, Z: 0}}
+
+	if hit, _, _ := pl.Intersect(ray); hit {
+		t.Fatalf("expected a ray parallel to the plane to miss")
+	}
+}
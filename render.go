@@ -0,0 +1,145 @@
+package rt
+
+import (
+	"image"
+	"math/rand"
+	"runtime"
+	"sync"
+)
+
+// defaultTileSize is the side length, in pixels, of each unit of work
+// dispatched to a render worker.
+const defaultTileSize = 32
+
+// TileDone reports that a tile has finished rendering, so callers can
+// show progress (e.g. repainting that rectangle of the image).
+type TileDone struct {
+	Rect  image.Rectangle
+	Index int
+}
+
+// RenderOptions configures Scene.RenderImage. Zero values pick sensible
+// defaults: a 32x32 tile size, one worker per CPU, a single sample per
+// pixel via the direct-illumination renderer.
+type RenderOptions struct {
+	TileSize int
+	Workers  int
+
+	// PathTrace selects Scene.PathTrace over Scene.Render for each
+	// pixel; SamplesPerPixel and MaxDepth are only meaningful when set.
+	PathTrace       bool
+	SamplesPerPixel int
+	MaxDepth        int
+
+	// Seed seeds each tile's RNG (see tileSeed), so that a given
+	// RenderOptions always produces the same image regardless of which
+	// worker goroutine happens to pick up which tile.
+	Seed int64
+
+	// Progress, if non-nil, receives a TileDone as each tile completes
+	// and is closed once the whole image has rendered.
+	Progress chan<- TileDone
+}
+
+// RenderImage renders the whole of img by partitioning it into tiles and
+// rendering them concurrently across opts.Workers goroutines. Tiles are
+// shuffled before dispatch so that a caller watching opts.Progress sees
+// coverage spread across the image early, rather than filling top to
+// bottom.
+func (s *Scene) RenderImage(img *image.RGBA, opts RenderOptions) {
+	tileSize := opts.TileSize
+	if tileSize <= 0 {
+		tileSize = defaultTileSize
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	samplesPerPixel := opts.SamplesPerPixel
+	if samplesPerPixel <= 0 {
+		samplesPerPixel = 1
+	}
+	maxDepth := opts.MaxDepth
+	if maxDepth <= 0 {
+		maxDepth = 8
+	}
+
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	s.SetPixelSize(2.0/float64(width), 2.0/float64(height))
+
+	// Build the BVH once, up front, so that no worker has to build it
+	// lazily (behind ensureBVH's sync.Once) while the others wait on it.
+	s.ensureBVH()
+
+	tiles := tilesFor(bounds, tileSize)
+	rand.New(rand.NewSource(opts.Seed)).Shuffle(len(tiles), func(i, j int) {
+		tiles[i], tiles[j] = tiles[j], tiles[i]
+	})
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				tile := tiles[idx]
+				// Seeded by tile index, not worker or goroutine
+				// scheduling order, so a tile renders identically no
+				// matter which worker happens to service it.
+				rng := rand.New(rand.NewSource(tileSeed(opts.Seed, idx)))
+				s.renderTile(img, tile, width, height, samplesPerPixel, maxDepth, opts.PathTrace, rng)
+				if opts.Progress != nil {
+					opts.Progress <- TileDone{Rect: tile, Index: idx}
+				}
+			}
+		}()
+	}
+
+	for i := range tiles {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if opts.Progress != nil {
+		close(opts.Progress)
+	}
+}
+
+// tileSeed derives a tile's RNG seed from the render's base seed and the
+// tile's index, so each tile's samples are reproducible independent of
+// which worker renders it.
+func tileSeed(base int64, tileIndex int) int64 {
+	return base + int64(tileIndex) + 1
+}
+
+// tilesFor partitions bounds into tileSize x tileSize rectangles,
+// clipped to bounds at the right and bottom edges.
+func tilesFor(bounds image.Rectangle, tileSize int) []image.Rectangle {
+	var tiles []image.Rectangle
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += tileSize {
+		for x := bounds.Min.X; x < bounds.Max.X; x += tileSize {
+			tiles = append(tiles, image.Rect(x, y, min(x+tileSize, bounds.Max.X), min(y+tileSize, bounds.Max.Y)))
+		}
+	}
+	return tiles
+}
+
+// renderTile fills in every pixel of tile in img
+func (s *Scene) renderTile(img *image.RGBA, tile image.Rectangle, width, height, samplesPerPixel, maxDepth int, pathTrace bool, rng *rand.Rand) {
+	for py := tile.Min.Y; py < tile.Max.Y; py++ {
+		for px := tile.Min.X; px < tile.Max.X; px++ {
+			x := float64(px)/float64(width)*2 - 1.0
+			y := float64(py)/float64(height)*2 - 1.0
+
+			if pathTrace {
+				img.Set(px, py, s.PathTrace(x, y, samplesPerPixel, maxDepth, rng))
+				continue
+			}
+			colour, _ := s.Render(x, y)
+			img.Set(px, py, colour)
+		}
+	}
+}
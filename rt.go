@@ -4,6 +4,7 @@ import (
 	"image"
 	"image/color"
 	"math"
+	"sync"
 )
 
 // P3 is a 3d point
@@ -81,11 +82,17 @@ func (t3 T3) normal() P3 {
 	return e1.Cross(e2)
 }
 
+// Bounds returns the axis-aligned bounding box of this triangle
+func (t3 T3) Bounds() AABB {
+	return boundsOfPoints(t3.A, t3.B, t3.C)
+}
+
 // Hit represents a ray hit on an item
 type Hit struct {
-	At     P3
-	Normal P3
-	Colour color.Color
+	At       P3
+	Normal   P3
+	Colour   color.Color
+	Material Material
 }
 
 // Intersect returns whether a ray intersects this triangle (and if so, the colour, position and normal)
@@ -96,7 +103,8 @@ func (t3 T3) Intersect(r R3) (bool, Hit, int64) {
 		return false, Hit{}, 1
 	}
 	//	return true, color.NRGBA{R: uint8(u * 255), G: uint8(v * 255), B: 0, A: 255}, p, t3.normal()
-	return true, Hit{p, t3.normal(), color.NRGBA{R: 128, G: 0, B: 0, A: 255}}, 1
+	colour := color.NRGBA{R: 128, G: 0, B: 0, A: 255}
+	return true, Hit{p, t3.normal(), colour, Lambertian{Albedo: colour}}, 1
 }
 
 // IntersectUV returns true/false for an intercept
@@ -183,54 +191,78 @@ func (k3 Kite3) Intersect(r R3) (bool, Hit, int64) {
 	// z-orderin/
 	hit, u, v, p := k3.TA.IntersectUV(r)
 	if hit {
-		return hit, Hit{p, k3.TA.normal(), uvToColor(u, v)}, 1
+		colour := uvToColor(u, v)
+		return hit, Hit{p, k3.TA.normal(), colour, Lambertian{Albedo: colour}}, 1
 	}
 	hit, u, v, p = k3.TB.IntersectUV(r)
 	u, v = 1-v, 1-u
 	if hit {
-		return hit, Hit{p, k3.TB.normal(), uvToColor(u, v)}, 2
+		colour := uvToColor(u, v)
+		return hit, Hit{p, k3.TB.normal(), colour, Lambertian{Albedo: colour}}, 2
 	}
 	return false, Hit{}, 2
 }
 
+// Bounds returns the axis-aligned bounding box of this kite
+func (k3 Kite3) Bounds() AABB {
+	return unionAABB(k3.TA.Bounds(), k3.TB.Bounds())
+}
+
 // An Item is something visible which can be added to a scene
 type Item interface {
 	Intersect(r R3) (bool, Hit, int64)
+	Bounds() AABB
 }
 
 type CompositeItem struct {
 	children []Item
+
+	// bvh accelerates Intersect over children. It's built lazily (see
+	// ensureBVH) since children are usually appended after construction,
+	// and guarded by bvhOnce so concurrent renders (RenderImage) can't
+	// race building it twice.
+	bvhOnce sync.Once
+	bvh     *BVH
 }
 
 func (ci *CompositeItem) AddItem(i Item) {
 	ci.children = append(ci.children, i)
 }
 
+// ensureBVH builds ci.bvh over ci.children on first use. Composites like
+// Torus/PPiped can hold hundreds of primitives, and without this a
+// Scene's BVH only ever accelerates its top-level items (a handful of
+// composites), never the primitives inside them.
+func (ci *CompositeItem) ensureBVH() {
+	ci.bvhOnce.Do(func() {
+		ci.bvh = NewBVH(ci.children)
+	})
+}
+
 func (ci *CompositeItem) Intersect(ray R3) (bool, Hit, int64) {
-	var hits []Hit
+	return ci.IntersectBounded(ray, math.Inf(1))
+}
+
+// IntersectBounded is Intersect, but ignores any hit with a ray parameter
+// greater than maxT (see BVH.IntersectBounded).
+func (ci *CompositeItem) IntersectBounded(ray R3, maxT float64) (bool, Hit, int64) {
 	if ci.children == nil {
 		panic("Null children")
 	}
-	var totalTests int64
-	for i := range ci.children {
-		intersects, h, numTests := ci.children[i].Intersect(ray)
-		totalTests += numTests
-		if intersects {
-			hits = append(hits, h)
-		}
-	}
+	ci.ensureBVH()
+	return ci.bvh.IntersectBounded(ray, maxT)
+}
 
-	if len(hits) == 0 {
-		return false, Hit{}, totalTests
+// Bounds returns the axis-aligned bounding box enclosing all children
+func (ci *CompositeItem) Bounds() AABB {
+	if len(ci.children) == 0 {
+		return AABB{}
 	}
-
-	nearestHit := hits[0]
-	for _, h := range hits {
-		if h.At.Len() < nearestHit.At.Len() {
-			nearestHit = h
-		}
+	bounds := ci.children[0].Bounds()
+	for _, c := range ci.children[1:] {
+		bounds = unionAABB(bounds, c.Bounds())
 	}
-	return true, nearestHit, totalTests
+	return bounds
 }
 
 // Scene contains the items, lighting and viewport
@@ -239,6 +271,18 @@ type Scene struct {
 	viewerDist float64
 	screenDist float64
 	lights     []Light
+
+	// pixelDX, pixelDY are the width of a pixel in the (x, y) co-ordinate
+	// space of Render/PathTrace, used to jitter path-traced samples
+	// across the pixel. See SetPixelSize.
+	pixelDX, pixelDY float64
+}
+
+// SetPixelSize records the width of a pixel in Render's (x, y) co-ordinate
+// space, so that PathTrace can jitter its samples across the whole pixel
+// rather than always sampling its centre.
+func (s *Scene) SetPixelSize(dx, dy float64) {
+	s.pixelDX, s.pixelDY = dx, dy
 }
 
 // Light represents a light source
@@ -256,22 +300,46 @@ func New(viewerDist float64, screenDist float64) *Scene {
 }
 
 func (s *Scene) illumination(hit Hit) color.Color {
-	// In the range 0->0xffff
-	r, g, b, _ := hit.Colour.RGBA()
+	surfR, surfG, surfB := colourToUnit(hit.Colour)
+	normal := hit.Normal.Normalise()
 
-	incidence := 0.0
+	var sumR, sumG, sumB float64
 	for _, light := range s.lights {
-		// TODO: check intersection with other scene items for shadows
-		vlight := hit.At.Sub(light.At)
-		incidence += math.Abs(vlight.Dot(hit.Normal) / vlight.Len() / hit.Normal.Len())
-		// TODO incorporate color of the light
+		toLight := light.At.Sub(hit.At)
+		distToLight := toLight.Len()
+		dir := toLight.Normalise()
+
+		ndotl := normal.Dot(dir)
+		if ndotl <= 0 {
+			// Light is behind the surface: no contribution (this also
+			// fixes the old code illuminating back faces, since it
+			// dotted the normal with vlight without checking the sign).
+			continue
+		}
+
+		shadowRay := R3{At: hit.At.Add(normal.Scale(Epsilon)), Dir: dir}
+		if s.shadowed(shadowRay, distToLight) {
+			continue
+		}
+
+		lightR, lightG, lightB := colourToUnit(light.Colour)
+		sumR += ndotl * lightR * surfR
+		sumG += ndotl * lightG * surfG
+		sumB += ndotl * lightB * surfB
 	}
 
-	incidence /= float64(len(s.lights))
-	//	fmt.Printf("incidence %f\n", incidence)
+	return unitToColour(sumR, sumG, sumB)
+}
 
-	toUint := func(v uint32) uint8 { return uint8(float64(v) * incidence / 0xffff * 256) }
-	return color.NRGBA{R: toUint(r), G: toUint(g), B: toUint(b), A: 255}
+// shadowed returns whether anything in the scene blocks r before it
+// reaches a light at distance distToLight
+func (s *Scene) shadowed(r R3, distToLight float64) bool {
+	intersects, hit, _ := s.IntersectBounded(r, distToLight)
+	if !intersects {
+		return false
+	}
+	t := rayParam(r, hit.At)
+	return t > Epsilon && t < distToLight
 }
 
 func (s *Scene) Render(x, y float64) (color.Color, int64) {
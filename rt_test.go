@@ -0,0 +1,53 @@
+package rt
+
+import (
+	"image/color"
+	"testing"
+)
+
+func TestIlluminationSkipsBackFacingLight(t *testing.T) {
+	s := New(5, 1)
+	s.AddLight(Light{At: P3{X: 0, Y: 0, Z: -10}, Colour: color.White})
+
+	hit := Hit{At: P3{X: 0, Y: 0, Z: 0}, Normal: P3{X: 0, Y: 0, Z: 1}, Colour: color.White}
+	r, g, b := colourToUnit(s.illumination(hit))
+	if r != 0 || g != 0 || b != 0 {
+		t.Fatalf("light behind the surface normal contributed (%v,%v,%v), want black", r, g, b)
+	}
+}
+
+func TestIlluminationUnoccludedLightContributes(t *testing.T) {
+	s := New(5, 1)
+	s.AddItem(Sphere{Centre: P3{X: 1000, Y: 1000, Z: 1000}, Radius: 1})
+	s.AddLight(Light{At: P3{X: 0, Y: 0, Z: -10}, Colour: color.White})
+
+	hit := Hit{At: P3{X: 0, Y: 0, Z: 0}, Normal: P3{X: 0, Y: 0, Z: -1}, Colour: color.White}
+	r, g, b := colourToUnit(s.illumination(hit))
+	if r == 0 && g == 0 && b == 0 {
+		t.Fatalf("expected an unoccluded, front-facing light to contribute")
+	}
+}
+
+func TestIlluminationSkipsOccludedLight(t *testing.T) {
+	s := New(5, 1)
+	// Sits directly between the hit point and the light.
+	s.AddItem(Sphere{Centre: P3{X: 0, Y: 0, Z: -5}, Radius: 1})
+	s.AddLight(Light{At: P3{X: 0, Y: 0, Z: -10}, Colour: color.White})
+
+	hit := Hit{At: P3{X: 0, Y: 0, Z: 0}, Normal: P3{X: 0, Y: 0, Z: -1}, Colour: color.White}
+	r, g, b := colourToUnit(s.illumination(hit))
+	if r != 0 || g != 0 || b != 0 {
+		t.Fatalf("occluded light contributed (%v,%v,%v), want black", r, g, b)
+	}
+}
+
+func TestShadowedIgnoresHitsBeyondTheLight(t *testing.T) {
+	s := New(5, 1)
+	// Beyond the light, so it must not count as a blocker.
+	s.AddItem(Sphere{Centre: P3{X: 0, Y: 0, Z: -20}, Radius: 1})
+
+	shadowRay := R3{At: P3{X: 0, Y: 0, Z: 0}, Dir: P3{X: 0, Y: 0, Z: -1}}
+	if s.shadowed(shadowRay, 10) {
+		t.Fatalf("expected a blocker beyond the light to be ignored")
+	}
+}